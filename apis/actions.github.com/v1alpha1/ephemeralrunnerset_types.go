@@ -0,0 +1,122 @@
+/*
+Copyright 2020 The actions-runner-controller authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EphemeralRunnerSetSpec defines the desired state of EphemeralRunnerSet
+type EphemeralRunnerSetSpec struct {
+	// Replicas is the number of desired EphemeralRunner resources in the EphemeralRunnerSet.
+	Replicas int `json:"replicas,omitempty"`
+
+	// EphemeralRunnerSpec is the spec used to create new EphemeralRunner resources.
+	EphemeralRunnerSpec EphemeralRunnerSpec `json:"ephemeralRunnerSpec"`
+
+	// ScaleDownPolicy controls the ordering, pacing, and drain behavior used when scaling
+	// down. Defaults to deleting the oldest idle EphemeralRunners first with no draining of
+	// busy runners, matching the historical behavior of this controller.
+	// +optional
+	ScaleDownPolicy ScaleDownPolicy `json:"scaleDownPolicy,omitempty"`
+
+	// ScaleUpPolicy throttles the pace of EphemeralRunner creation when scaling up, and backs
+	// off when recently-created runners are stalling on registration with the Actions
+	// service. Defaults to unthrottled, sequential creation, matching the historical
+	// behavior of this controller.
+	// +optional
+	ScaleUpPolicy ScaleUpPolicy `json:"scaleUpPolicy,omitempty"`
+
+	// PreserveRunnersOnDeletion, when true, keeps EphemeralRunners that are currently
+	// executing a job alive across deletion of this EphemeralRunnerSet instead of removing
+	// them from the Actions service. They are detached from this EphemeralRunnerSet and left
+	// to finish their job and self-deregister via the EphemeralRunner controller, so
+	// replacing an EphemeralRunnerSet (e.g. to change its image) does not kill in-flight jobs.
+	// +optional
+	PreserveRunnersOnDeletion bool `json:"preserveRunnersOnDeletion,omitempty"`
+}
+
+// RunnerReference identifies a single EphemeralRunner that is currently executing a job,
+// along with the job metadata reported by the Actions service.
+type RunnerReference struct {
+	// RunnerName is the name of the EphemeralRunner resource.
+	RunnerName string `json:"runnerName"`
+
+	// RunnerId is the id assigned to the runner by the Actions service.
+	RunnerId int `json:"runnerId"`
+
+	// JobRequestId is the id of the job currently assigned to the runner.
+	JobRequestId int64 `json:"jobRequestId"`
+
+	// JobDisplayName is the display name of the job currently assigned to the runner.
+	JobDisplayName string `json:"jobDisplayName,omitempty"`
+
+	// StartedAt is the time the job was observed to start on this runner.
+	StartedAt metav1.Time `json:"startedAt,omitempty"`
+}
+
+const (
+	// EphemeralRunnerSetConditionTypeReady indicates whether the EphemeralRunnerSet has
+	// reconciled its desired replica count and all children are healthy.
+	EphemeralRunnerSetConditionTypeReady = "Ready"
+
+	// EphemeralRunnerSetConditionTypeRunnersOrphaned lists, in its message, the
+	// EphemeralRunners that were detached from a deleted EphemeralRunnerSet under
+	// PreserveRunnersOnDeletion so they could finish their in-flight job.
+	EphemeralRunnerSetConditionTypeRunnersOrphaned = "RunnersOrphaned"
+
+	// EphemeralRunnerSetConditionTypeScaleUpStalled names, in its message, the
+	// EphemeralRunners that have exceeded ScaleUpPolicy.RegistrationTimeout without
+	// registering with the Actions service, causing the controller to back off further
+	// creations.
+	EphemeralRunnerSetConditionTypeScaleUpStalled = "ScaleUpStalled"
+)
+
+// EphemeralRunnerSetStatus defines the observed state of EphemeralRunnerSet
+type EphemeralRunnerSetStatus struct {
+	// CurrentReplicas is the total number of pending, running and failed EphemeralRunners
+	// currently owned by the EphemeralRunnerSet; finished and deleting ones are not counted.
+	CurrentReplicas int `json:"currentReplicas"`
+
+	// PendingEphemeralRunners is the number of EphemeralRunners in the Pending phase, or
+	// with no phase set yet.
+	PendingEphemeralRunners int `json:"pendingEphemeralRunners"`
+
+	// RunningEphemeralRunners is the number of EphemeralRunners in the Running phase,
+	// whether or not they are currently executing a job.
+	RunningEphemeralRunners int `json:"runningEphemeralRunners"`
+
+	// FinishedEphemeralRunners is the number of EphemeralRunners that completed successfully
+	// and are pending deletion.
+	FinishedEphemeralRunners int `json:"finishedEphemeralRunners"`
+
+	// FailedEphemeralRunners is the number of EphemeralRunners that failed.
+	FailedEphemeralRunners int `json:"failedEphemeralRunners"`
+
+	// DeletingEphemeralRunners is the number of EphemeralRunners currently being deleted.
+	DeletingEphemeralRunners int `json:"deletingEphemeralRunners"`
+
+	// RunningEphemeralRunnersDetail lists the EphemeralRunners that are currently executing
+	// a job, along with the job metadata reported by the Actions service.
+	// +optional
+	RunningEphemeralRunnersDetail []RunnerReference `json:"runningEphemeralRunnersDetail,omitempty"`
+
+	// Conditions is the set of aggregated conditions rolled up from the child EphemeralRunners,
+	// including a top-level Ready condition.
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
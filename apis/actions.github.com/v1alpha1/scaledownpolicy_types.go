@@ -0,0 +1,87 @@
+/*
+Copyright 2020 The actions-runner-controller authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// ScaleDownStrategy selects the order in which idle EphemeralRunners are considered for
+// deletion when scaling down an EphemeralRunnerSet.
+type ScaleDownStrategy string
+
+const (
+	// ScaleDownStrategyOldestFirst deletes pending EphemeralRunners before running ones, the
+	// longest-lived first within each group. This is the default and matches the historical
+	// behavior of this controller.
+	ScaleDownStrategyOldestFirst ScaleDownStrategy = "OldestFirst"
+
+	// ScaleDownStrategyNewestFirst deletes the most recently created EphemeralRunners first.
+	ScaleDownStrategyNewestFirst ScaleDownStrategy = "NewestFirst"
+
+	// ScaleDownStrategyLeastRecentlyStarted deletes EphemeralRunners that have gone the
+	// longest without picking up a job first, favoring runners that are actively cycling
+	// through jobs.
+	ScaleDownStrategyLeastRecentlyStarted ScaleDownStrategy = "LeastRecentlyStarted"
+
+	// ScaleDownStrategyRandom deletes EphemeralRunners in a random order.
+	ScaleDownStrategyRandom ScaleDownStrategy = "Random"
+)
+
+// DrainTimeoutAction controls what happens to a busy EphemeralRunner that is still running a
+// job once its DrainTimeout has elapsed.
+type DrainTimeoutAction string
+
+const (
+	// DrainTimeoutActionForce deletes the EphemeralRunner once DrainTimeout has elapsed,
+	// even though it is still running a job.
+	DrainTimeoutActionForce DrainTimeoutAction = "Force"
+
+	// DrainTimeoutActionPreserve leaves the EphemeralRunner running past DrainTimeout. It
+	// remains marked for scale down and will be retried on the next reconcile.
+	DrainTimeoutActionPreserve DrainTimeoutAction = "Preserve"
+)
+
+// ScaleDownPolicy controls how an EphemeralRunnerSet picks EphemeralRunners to delete when
+// scaling down, and how it handles runners that are busy running a job.
+type ScaleDownPolicy struct {
+	// Strategy is the ordering used to pick EphemeralRunners for deletion.
+	// Defaults to OldestFirst.
+	// +optional
+	// +kubebuilder:validation:Enum=OldestFirst;NewestFirst;LeastRecentlyStarted;Random
+	Strategy ScaleDownStrategy `json:"strategy,omitempty"`
+
+	// MaxUnavailable caps how many EphemeralRunners may be deleted or marked for drain in a
+	// single reconcile pass, as an absolute number or a percentage (rounded up) of the pending
+	// and running EphemeralRunners. Defaults to the full scale down delta.
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+
+	// DrainTimeout is how long a busy EphemeralRunner (one with JobRequestId set) is given
+	// to finish its job once marked for scale down before OnDrainTimeout is applied. A zero
+	// value means busy runners are never drained and are skipped indefinitely, matching the
+	// historical behavior of this controller.
+	// +optional
+	DrainTimeout metav1.Duration `json:"drainTimeout,omitempty"`
+
+	// OnDrainTimeout controls what happens to a busy EphemeralRunner once DrainTimeout has
+	// elapsed. Defaults to Preserve.
+	// +optional
+	// +kubebuilder:validation:Enum=Force;Preserve
+	OnDrainTimeout DrainTimeoutAction `json:"onDrainTimeout,omitempty"`
+}
@@ -0,0 +1,51 @@
+/*
+Copyright 2020 The actions-runner-controller authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ScaleUpPolicy throttles how aggressively an EphemeralRunnerSet creates new EphemeralRunners,
+// so a large Spec.Replicas bump does not burst against the Actions service secret exchange all
+// at once.
+type ScaleUpPolicy struct {
+	// MaxSurge is the number of recently-created EphemeralRunners that are allowed to be
+	// still registering (Status.RunnerId == 0) past RegistrationTimeout before the
+	// controller backs off subsequent creations. Defaults to 0, meaning any stuck
+	// registration triggers backoff.
+	// +optional
+	MaxSurge int `json:"maxSurge,omitempty"`
+
+	// MaxConcurrentCreations caps how many new EphemeralRunner objects are created in a
+	// single reconcile pass. A zero value means unlimited, matching the historical behavior
+	// of this controller.
+	// +optional
+	MaxConcurrentCreations int `json:"maxConcurrentCreations,omitempty"`
+
+	// RegistrationTimeout is how long a newly-created EphemeralRunner is given to register
+	// with the Actions service (Status.RunnerId set) before it counts toward MaxSurge.
+	// +optional
+	RegistrationTimeout metav1.Duration `json:"registrationTimeout,omitempty"`
+
+	// FailureBackoff is the base delay used to back off subsequent creations once MaxSurge
+	// is exceeded. The delay doubles for every FailureBackoff interval the oldest stalled
+	// EphemeralRunner has spent past RegistrationTimeout, up to 64 times this base. A zero
+	// value uses a base of one second.
+	// +optional
+	FailureBackoff metav1.Duration `json:"failureBackoff,omitempty"`
+}
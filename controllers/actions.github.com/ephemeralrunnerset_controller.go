@@ -20,22 +20,29 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math/rand"
 	"net/http"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/actions/actions-runner-controller/apis/actions.github.com/v1alpha1"
 	"github.com/actions/actions-runner-controller/github/actions"
+	"github.com/actions/actions-runner-controller/internal/finalizers"
 	"github.com/go-logr/logr"
 	"go.uber.org/multierr"
 	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 )
 
@@ -65,10 +72,10 @@ type EphemeralRunnerSetReconciler struct {
 // The safe point where we can patch the resource is when we are reacting on finalizer.
 // Then, the listener should be deleted first, to allow controller clean up resources without interruptions
 //
-// The resource should be created with finalizer. To leave it to this controller to add it, we would
-// risk the same issue of patching the status. Responsibility of this controller should only
-// be to bring the count of EphemeralRunners to the desired one, not to patch this resource
-// until it is safe to do so
+// Finalizer presence is handled uniformly by finalizers.EnsureFinalizer before any Get/List
+// work below, so this controller's Reconcile body can assume it is already set. Responsibility
+// of this controller should only be to bring the count of EphemeralRunners to the desired one,
+// not to patch this resource until it is safe to do so
 func (r *EphemeralRunnerSetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := r.Log.WithValues("ephemeralrunnerset", req.NamespacedName)
 
@@ -77,6 +84,19 @@ func (r *EphemeralRunnerSetReconciler) Reconcile(ctx context.Context, req ctrl.R
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
+	// Ensure the finalizer is present before any Get/List work below. This is a no-op when
+	// the resource is already being deleted, and added is true only when this call is the one
+	// that added it, in which case we return early and let the next reconcile do the rest.
+	added, err := finalizers.EnsureFinalizer(ctx, r.Client, ephemeralRunnerSet, ephemeralRunnerSetFinalizerName)
+	if err != nil {
+		log.Error(err, "Failed to ensure finalizer")
+		return ctrl.Result{}, err
+	}
+	if added {
+		log.Info("Successfully added finalizer")
+		return ctrl.Result{}, nil
+	}
+
 	// Requested deletion does not need reconciled.
 	if !ephemeralRunnerSet.ObjectMeta.DeletionTimestamp.IsZero() {
 		if controllerutil.ContainsFinalizer(ephemeralRunnerSet, ephemeralRunnerSetFinalizerName) {
@@ -105,23 +125,9 @@ func (r *EphemeralRunnerSetReconciler) Reconcile(ctx context.Context, req ctrl.R
 		return ctrl.Result{}, nil
 	}
 
-	// Add finalizer if not present
-	if !controllerutil.ContainsFinalizer(ephemeralRunnerSet, ephemeralRunnerSetFinalizerName) {
-		log.Info("Adding finalizer")
-		if err := patch(ctx, r.Client, ephemeralRunnerSet, func(obj *v1alpha1.EphemeralRunnerSet) {
-			controllerutil.AddFinalizer(obj, ephemeralRunnerSetFinalizerName)
-		}); err != nil {
-			log.Error(err, "Failed to update ephemeral runner set with finalizer added")
-			return ctrl.Result{}, err
-		}
-
-		log.Info("Successfully added finalizer")
-		return ctrl.Result{}, nil
-	}
-
 	// Find all EphemeralRunner with matching namespace and own by this EphemeralRunnerSet.
 	ephemeralRunnerList := new(v1alpha1.EphemeralRunnerList)
-	err := r.List(
+	err = r.List(
 		ctx,
 		ephemeralRunnerList,
 		client.InNamespace(req.Namespace),
@@ -161,36 +167,144 @@ func (r *EphemeralRunnerSetReconciler) Reconcile(ctx context.Context, req ctrl.R
 
 	total := len(pendingEphemeralRunners) + len(runningEphemeralRunners) + len(failedEphemeralRunners)
 	log.Info("Scaling comparison", "current", total, "desired", ephemeralRunnerSet.Spec.Replicas)
+	var result ctrl.Result
 	switch {
 	case total < ephemeralRunnerSet.Spec.Replicas: // Handle scale up
 		count := ephemeralRunnerSet.Spec.Replicas - total
 		log.Info("Creating new ephemeral runners (scale up)", "count", count)
-		if err := r.createEphemeralRunners(ctx, ephemeralRunnerSet, count, log); err != nil {
+		scaleUpResult, err := r.createEphemeralRunners(ctx, ephemeralRunnerSet, count, pendingEphemeralRunners, log)
+		if err != nil {
 			log.Error(err, "failed to make ephemeral runner")
 			return ctrl.Result{}, err
 		}
+		result = scaleUpResult
+
+		// Scaling up implies we are not scaling down this pass: clear any pending-scaledown
+		// annotation left over from a prior scale down, so a runner that was marked for drain
+		// but never reached DrainTimeout before the set scaled back up doesn't carry its old
+		// mark timestamp into a later scale down.
+		if err := r.unmarkStaleScaleDownAnnotations(ctx, pendingEphemeralRunners, runningEphemeralRunners, log); err != nil {
+			log.Error(err, "failed to clear stale pending-scaledown annotations")
+			return ctrl.Result{}, err
+		}
 
 	case total > ephemeralRunnerSet.Spec.Replicas: // Handle scale down scenario.
 		count := total - ephemeralRunnerSet.Spec.Replicas
 		log.Info("Deleting ephemeral runners (scale down)", "count", count)
-		if err := r.deleteIdleEphemeralRunners(ctx, ephemeralRunnerSet, pendingEphemeralRunners, runningEphemeralRunners, count, log); err != nil {
+		scaleDownResult, err := r.deleteIdleEphemeralRunners(ctx, ephemeralRunnerSet, pendingEphemeralRunners, runningEphemeralRunners, count, log)
+		if err != nil {
 			log.Error(err, "failed to delete idle runners")
 			return ctrl.Result{}, err
 		}
+		result = scaleDownResult
+
+	default:
+		// Not scaling down this pass: clear any pending-scaledown annotation left over from a
+		// prior scale down, so a runner that was marked for drain but never reached DrainTimeout
+		// before the set settled back at its desired replica count doesn't carry its old mark
+		// timestamp into a later scale down.
+		if err := r.unmarkStaleScaleDownAnnotations(ctx, pendingEphemeralRunners, runningEphemeralRunners, log); err != nil {
+			log.Error(err, "failed to clear stale pending-scaledown annotations")
+			return ctrl.Result{}, err
+		}
 	}
 
-	// Update the status if needed.
-	if ephemeralRunnerSet.Status.CurrentReplicas != total {
-		log.Info("Updating status with current runners count", "count", total)
-		if err := patch(ctx, r.Status(), ephemeralRunnerSet, func(obj *v1alpha1.EphemeralRunnerSet) {
-			obj.Status.CurrentReplicas = total
-		}); err != nil {
-			log.Error(err, "Failed to update status with current runners count")
-			return ctrl.Result{}, err
+	// Aggregate the per-phase counts and per-runner job detail, and patch the status
+	// subresource only if the aggregation actually changed. This avoids conflicting with
+	// the frequent replica-count patches the listener is doing, per the warning above.
+	newStatus := aggregateEphemeralRunnerSetStatus(total, pendingEphemeralRunners, runningEphemeralRunners, finishedEphemeralRunners, failedEphemeralRunners, deletingEphemeralRunners, ephemeralRunnerSet.Spec.Replicas, ephemeralRunnerSet.Status.Conditions)
+	if err := r.patchEphemeralRunnerSetStatus(ctx, ephemeralRunnerSet, newStatus, log); err != nil {
+		log.Error(err, "Failed to patch status with aggregated runner state")
+		return ctrl.Result{}, err
+	}
+
+	return result, nil
+}
+
+// aggregateEphemeralRunnerSetStatus rolls up the categorized child EphemeralRunners into the
+// status subresource shape, including the Ready condition. existingConditions seeds the result
+// so sibling conditions maintained by other code paths (RunnersOrphaned, ScaleUpStalled) survive
+// this roll-up instead of being dropped. It does not touch the API server; callers diff the
+// result against the current status via patchEphemeralRunnerSetStatus.
+func aggregateEphemeralRunnerSetStatus(total int, pending, running, finished, failed, deleting []*v1alpha1.EphemeralRunner, desiredReplicas int, existingConditions []metav1.Condition) v1alpha1.EphemeralRunnerSetStatus {
+	status := v1alpha1.EphemeralRunnerSetStatus{
+		CurrentReplicas:               total,
+		PendingEphemeralRunners:       len(pending),
+		RunningEphemeralRunners:       len(running),
+		FinishedEphemeralRunners:      len(finished),
+		FailedEphemeralRunners:        len(failed),
+		DeletingEphemeralRunners:      len(deleting),
+		RunningEphemeralRunnersDetail: runnerReferences(running),
+		Conditions:                    append([]metav1.Condition(nil), existingConditions...),
+	}
+
+	readyCondition := metav1.Condition{
+		Type:    v1alpha1.EphemeralRunnerSetConditionTypeReady,
+		Status:  metav1.ConditionTrue,
+		Reason:  "DesiredReplicasMet",
+		Message: "The EphemeralRunnerSet has the desired number of healthy EphemeralRunners",
+	}
+	if total != desiredReplicas || len(failed) > 0 {
+		readyCondition.Status = metav1.ConditionFalse
+		readyCondition.Reason = "ReconcilingReplicas"
+		readyCondition.Message = fmt.Sprintf("Have %d of %d desired EphemeralRunners, %d failed", total, desiredReplicas, len(failed))
+	}
+	apimeta.SetStatusCondition(&status.Conditions, readyCondition)
+
+	return status
+}
+
+// runnerReferences extracts the job metadata the listener and operators care about from the
+// currently-running EphemeralRunners.
+func runnerReferences(running []*v1alpha1.EphemeralRunner) []v1alpha1.RunnerReference {
+	if len(running) == 0 {
+		return nil
+	}
+
+	var refs []v1alpha1.RunnerReference
+	for _, r := range running {
+		if r.Status.JobRequestId == 0 {
+			// Running but idle: not executing a job.
+			continue
+		}
+		refs = append(refs, v1alpha1.RunnerReference{
+			RunnerName:     r.Name,
+			RunnerId:       r.Status.RunnerId,
+			JobRequestId:   r.Status.JobRequestId,
+			JobDisplayName: r.Status.JobDisplayName,
+			StartedAt:      r.Status.JobStartedAt,
+		})
+	}
+	return refs
+}
+
+// patchEphemeralRunnerSetStatus is the write path for the aggregated counts, running-runner
+// detail and Ready condition on this reconciler's status subresource; the RunnersOrphaned and
+// ScaleUpStalled conditions are patched by recordOrphanedRunners, recordScaleUpStalled and
+// clearScaleUpStalled. newStatus.Conditions is expected to already carry forward every condition
+// currently on the object (see aggregateEphemeralRunnerSetStatus), so this only preserves each
+// condition's LastTransitionTime across patches and issues a patch when the aggregated status
+// actually changed, so it never races with the listener's frequent replica-count patches.
+func (r *EphemeralRunnerSetReconciler) patchEphemeralRunnerSetStatus(ctx context.Context, ephemeralRunnerSet *v1alpha1.EphemeralRunnerSet, newStatus v1alpha1.EphemeralRunnerSetStatus, log logr.Logger) error {
+	for i := range newStatus.Conditions {
+		if existing := apimeta.FindStatusCondition(ephemeralRunnerSet.Status.Conditions, newStatus.Conditions[i].Type); existing != nil && existing.Status == newStatus.Conditions[i].Status {
+			newStatus.Conditions[i].LastTransitionTime = existing.LastTransitionTime
 		}
 	}
 
-	return ctrl.Result{}, nil
+	if apiequality.Semantic.DeepEqual(ephemeralRunnerSet.Status, newStatus) {
+		return nil
+	}
+
+	log.Info("Updating status with aggregated runner state",
+		"current", newStatus.CurrentReplicas,
+		"pending", newStatus.PendingEphemeralRunners,
+		"running", newStatus.RunningEphemeralRunners,
+		"failed", newStatus.FailedEphemeralRunners,
+	)
+	return patch(ctx, r.Status(), ephemeralRunnerSet, func(obj *v1alpha1.EphemeralRunnerSet) {
+		obj.Status = newStatus
+	})
 }
 
 func (r *EphemeralRunnerSetReconciler) cleanUpEphemeralRunners(ctx context.Context, ephemeralRunnerSet *v1alpha1.EphemeralRunnerSet, log logr.Logger) (done bool, err error) {
@@ -200,10 +314,12 @@ func (r *EphemeralRunnerSetReconciler) cleanUpEphemeralRunners(ctx context.Conte
 		return false, fmt.Errorf("failed to list child ephemeral runners: %v", err)
 	}
 
-	// only if there are no ephemeral runners left, return true
+	// Once we have no more owned children, the only thing that can still be keeping this
+	// EphemeralRunnerSet alive is EphemeralRunners orphaned earlier under
+	// PreserveRunnersOnDeletion: they no longer carry the owner reference this List matched on,
+	// but the finalizer must still wait for them to finish and self-deregister.
 	if len(ephemeralRunnerList.Items) == 0 {
-		log.Info("All ephemeral runners are deleted")
-		return true, nil
+		return r.orphanedEphemeralRunnersFinished(ctx, ephemeralRunnerSet, log)
 	}
 
 	pendingEphemeralRunners, runningEphemeralRunners, finishedEphemeralRunners, failedEphemeralRunners, deletingEphemeralRunners := categorizeEphemeralRunners(ephemeralRunnerList)
@@ -243,7 +359,18 @@ func (r *EphemeralRunnerSetReconciler) cleanUpEphemeralRunners(ctx context.Conte
 
 	log.Info("Cleanup pending or running ephemeral runners")
 	errs = errs[0:0]
+	var orphaned []string
 	for _, ephemeralRunner := range append(pendingEphemeralRunners, runningEphemeralRunners...) {
+		if ephemeralRunnerSet.Spec.PreserveRunnersOnDeletion && ephemeralRunner.Status.JobRequestId > 0 {
+			log.Info("Detaching busy ephemeral runner instead of removing it", "name", ephemeralRunner.Name, "jobRequestId", ephemeralRunner.Status.JobRequestId)
+			if err := r.detachEphemeralRunner(ctx, ephemeralRunnerSet, ephemeralRunner, log); err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			orphaned = append(orphaned, ephemeralRunner.Name)
+			continue
+		}
+
 		log.Info("Removing the ephemeral runner from the service", "name", ephemeralRunner.Name)
 		_, err := r.deleteEphemeralRunnerWithActionsClient(ctx, ephemeralRunner, actionsClient, log)
 		if err != nil {
@@ -257,14 +384,113 @@ func (r *EphemeralRunnerSetReconciler) cleanUpEphemeralRunners(ctx context.Conte
 		return false, mergedErrs
 	}
 
+	if len(orphaned) > 0 {
+		if err := r.recordOrphanedRunners(ctx, ephemeralRunnerSet, orphaned, log); err != nil {
+			log.Error(err, "Failed to record orphaned ephemeral runners on status")
+			return false, err
+		}
+	}
+
 	return false, nil
 }
 
-// createEphemeralRunners provisions `count` number of v1alpha1.EphemeralRunner resources in the cluster.
-func (r *EphemeralRunnerSetReconciler) createEphemeralRunners(ctx context.Context, runnerSet *v1alpha1.EphemeralRunnerSet, count int, log logr.Logger) error {
+// ephemeralRunnerOrphanedFromLabelName marks an EphemeralRunner that was detached from a
+// deleted EphemeralRunnerSet under PreserveRunnersOnDeletion, naming the EphemeralRunnerSet it
+// used to belong to. The EphemeralRunner controller does not treat this label specially;
+// orphanedEphemeralRunnersFinished lists by it to hold back the EphemeralRunnerSet finalizer,
+// and operators can use it to find orphaned runners.
+const ephemeralRunnerOrphanedFromLabelName = "actions.github.com/orphaned-from"
+
+// detachEphemeralRunner clears the controller owner reference on a busy EphemeralRunner so it
+// survives its EphemeralRunnerSet's deletion, and relabels it as orphaned. The EphemeralRunner
+// continues running its job and self-deregisters via its own controller when finished; after
+// that this reconciler only looks it up by label, in orphanedEphemeralRunnersFinished.
+func (r *EphemeralRunnerSetReconciler) detachEphemeralRunner(ctx context.Context, ephemeralRunnerSet *v1alpha1.EphemeralRunnerSet, ephemeralRunner *v1alpha1.EphemeralRunner, log logr.Logger) error {
+	return patch(ctx, r.Client, ephemeralRunner, func(obj *v1alpha1.EphemeralRunner) {
+		if err := controllerutil.RemoveControllerReference(ephemeralRunnerSet, obj, r.Scheme); err != nil {
+			log.Error(err, "Failed to remove controller reference while detaching ephemeral runner, continuing", "name", obj.Name)
+		}
+		if obj.Labels == nil {
+			obj.Labels = map[string]string{}
+		}
+		obj.Labels[ephemeralRunnerOrphanedFromLabelName] = ephemeralRunnerSet.Name
+	})
+}
+
+// recordOrphanedRunners patches the RunnersOrphaned condition with the names of the
+// EphemeralRunners detached during this deletion, so operators can find and track them.
+func (r *EphemeralRunnerSetReconciler) recordOrphanedRunners(ctx context.Context, ephemeralRunnerSet *v1alpha1.EphemeralRunnerSet, orphaned []string, log logr.Logger) error {
+	log.Info("Recording orphaned ephemeral runners", "names", orphaned)
+	return patch(ctx, r.Status(), ephemeralRunnerSet, func(obj *v1alpha1.EphemeralRunnerSet) {
+		apimeta.SetStatusCondition(&obj.Status.Conditions, metav1.Condition{
+			Type:    v1alpha1.EphemeralRunnerSetConditionTypeRunnersOrphaned,
+			Status:  metav1.ConditionTrue,
+			Reason:  "PreserveRunnersOnDeletion",
+			Message: fmt.Sprintf("Detached in-flight ephemeral runners: %s", strings.Join(orphaned, ", ")),
+		})
+	})
+}
+
+// orphanedEphemeralRunnersFinished reports whether every EphemeralRunner previously detached
+// from this EphemeralRunnerSet under PreserveRunnersOnDeletion has finished its job and been
+// cleaned up by the EphemeralRunner controller. Detached runners no longer carry the controller
+// owner reference cleanUpEphemeralRunners lists by, so they are found here by
+// ephemeralRunnerOrphanedFromLabelName instead. The EphemeralRunnerSet finalizer must not be
+// removed while any are still present, so that replacing an EphemeralRunnerSet really does wait
+// for in-flight jobs to finish rather than tearing down as soon as they're detached.
+func (r *EphemeralRunnerSetReconciler) orphanedEphemeralRunnersFinished(ctx context.Context, ephemeralRunnerSet *v1alpha1.EphemeralRunnerSet, log logr.Logger) (bool, error) {
+	orphanedList := new(v1alpha1.EphemeralRunnerList)
+	if err := r.List(
+		ctx,
+		orphanedList,
+		client.InNamespace(ephemeralRunnerSet.Namespace),
+		client.MatchingLabels{ephemeralRunnerOrphanedFromLabelName: ephemeralRunnerSet.Name},
+	); err != nil {
+		return false, fmt.Errorf("failed to list orphaned ephemeral runners: %w", err)
+	}
+
+	if len(orphanedList.Items) > 0 {
+		log.Info("Waiting for orphaned ephemeral runners to finish before removing finalizer", "count", len(orphanedList.Items))
+		return false, nil
+	}
+
+	log.Info("All ephemeral runners are deleted")
+	return true, nil
+}
+
+// createEphemeralRunners provisions up to `count` number of v1alpha1.EphemeralRunner resources
+// in the cluster, throttled by runnerSet.Spec.ScaleUpPolicy. If more than MaxSurge of the
+// still-registering EphemeralRunners (Status.RunnerId == 0) have exceeded
+// RegistrationTimeout, it backs off further creations with an exponential delay instead of
+// piling more runners onto a possibly-struggling Actions service secret exchange, and surfaces
+// a ScaleUpStalled condition naming the stuck runners.
+func (r *EphemeralRunnerSetReconciler) createEphemeralRunners(ctx context.Context, runnerSet *v1alpha1.EphemeralRunnerSet, count int, pendingEphemeralRunners []*v1alpha1.EphemeralRunner, log logr.Logger) (ctrl.Result, error) {
+	policy := runnerSet.Spec.ScaleUpPolicy
+
+	if policy.RegistrationTimeout.Duration > 0 {
+		stalled := stalledEphemeralRunners(registeringEphemeralRunners(pendingEphemeralRunners), policy.RegistrationTimeout.Duration, policy.MaxSurge)
+		if len(stalled) > 0 {
+			backoff := scaleUpBackoff(stalled, policy)
+			log.Info("Scale up stalled, backing off further creations", "stalled", runnerNames(stalled), "backoff", backoff)
+			if err := r.recordScaleUpStalled(ctx, runnerSet, stalled, log); err != nil {
+				return ctrl.Result{}, err
+			}
+			return ctrl.Result{RequeueAfter: backoff}, nil
+		}
+	}
+
+	if err := r.clearScaleUpStalled(ctx, runnerSet, log); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	maxConcurrent := count
+	if policy.MaxConcurrentCreations > 0 && policy.MaxConcurrentCreations < maxConcurrent {
+		maxConcurrent = policy.MaxConcurrentCreations
+	}
+
 	// Track multiple errors at once and return the bundle.
 	errs := make([]error, 0)
-	for i := 0; i < count; i++ {
+	for i := 0; i < maxConcurrent; i++ {
 		ephemeralRunner := r.resourceBuilder.newEphemeralRunner(runnerSet)
 
 		// Make sure that we own the resource we create.
@@ -274,7 +500,7 @@ func (r *EphemeralRunnerSetReconciler) createEphemeralRunners(ctx context.Contex
 			continue
 		}
 
-		log.Info("Creating new ephemeral runner", "progress", i+1, "total", count)
+		log.Info("Creating new ephemeral runner", "progress", i+1, "total", maxConcurrent)
 		if err := r.Create(ctx, ephemeralRunner); err != nil {
 			log.Error(err, "failed to make ephemeral runner")
 			errs = append(errs, err)
@@ -284,29 +510,214 @@ func (r *EphemeralRunnerSetReconciler) createEphemeralRunners(ctx context.Contex
 		log.Info("Created new ephemeral runner", "runner", ephemeralRunner.Name)
 	}
 
+	var result ctrl.Result
+	if maxConcurrent < count {
+		// More EphemeralRunners are desired than we were willing to create this pass;
+		// come back around without waiting for an external event.
+		result.Requeue = true
+	}
+
+	return result, multierr.Combine(errs...)
+}
+
+// registeringEphemeralRunners returns the pending EphemeralRunners that have not yet
+// registered with the Actions service.
+func registeringEphemeralRunners(pending []*v1alpha1.EphemeralRunner) []*v1alpha1.EphemeralRunner {
+	var registering []*v1alpha1.EphemeralRunner
+	for _, r := range pending {
+		if r.Status.RunnerId == 0 {
+			registering = append(registering, r)
+		}
+	}
+	return registering
+}
+
+// stalledEphemeralRunners returns the registering EphemeralRunners that have exceeded
+// RegistrationTimeout, but only once there are more of them than MaxSurge allows.
+func stalledEphemeralRunners(registering []*v1alpha1.EphemeralRunner, registrationTimeout time.Duration, maxSurge int) []*v1alpha1.EphemeralRunner {
+	var stalled []*v1alpha1.EphemeralRunner
+	for _, r := range registering {
+		if time.Since(r.GetCreationTimestamp().Time) > registrationTimeout {
+			stalled = append(stalled, r)
+		}
+	}
+	if len(stalled) <= maxSurge {
+		return nil
+	}
+	return stalled
+}
+
+// scaleUpBackoff computes an exponential delay from how long the oldest stalled EphemeralRunner
+// has been stuck past RegistrationTimeout, capped to avoid growing unbounded.
+func scaleUpBackoff(stalled []*v1alpha1.EphemeralRunner, policy v1alpha1.ScaleUpPolicy) time.Duration {
+	base := policy.FailureBackoff.Duration
+	if base <= 0 {
+		base = time.Second
+	}
+
+	oldest := stalled[0].GetCreationTimestamp().Time
+	for _, r := range stalled[1:] {
+		if t := r.GetCreationTimestamp().Time; t.Before(oldest) {
+			oldest = t
+		}
+	}
+
+	const maxDoublings = 6 // caps backoff at 64x the base delay
+	doublings := int((time.Since(oldest) - policy.RegistrationTimeout.Duration) / base)
+	if doublings > maxDoublings {
+		doublings = maxDoublings
+	}
+
+	return base << doublings
+}
+
+// runnerNames extracts EphemeralRunner names for logging and status messages.
+func runnerNames(runners []*v1alpha1.EphemeralRunner) []string {
+	names := make([]string, 0, len(runners))
+	for _, r := range runners {
+		names = append(names, r.Name)
+	}
+	return names
+}
+
+// recordScaleUpStalled patches the ScaleUpStalled condition naming the EphemeralRunners that
+// are stuck registering, skipping the patch if the condition already reports the same runners.
+// This patches runnerSet.Status.Conditions directly (in place) rather than waiting for the
+// Reconcile-level status patch, so the condition is visible immediately even though
+// createEphemeralRunners returns early on the stalled path. Reconcile's later call to
+// aggregateEphemeralRunnerSetStatus reads runnerSet.Status.Conditions as its starting point, so
+// it must keep carrying forward whatever this call just set rather than rebuilding the
+// condition list from scratch.
+func (r *EphemeralRunnerSetReconciler) recordScaleUpStalled(ctx context.Context, runnerSet *v1alpha1.EphemeralRunnerSet, stalled []*v1alpha1.EphemeralRunner, log logr.Logger) error {
+	message := fmt.Sprintf("EphemeralRunners stuck registering past RegistrationTimeout: %s", strings.Join(runnerNames(stalled), ", "))
+	if existing := apimeta.FindStatusCondition(runnerSet.Status.Conditions, v1alpha1.EphemeralRunnerSetConditionTypeScaleUpStalled); existing != nil && existing.Status == metav1.ConditionTrue && existing.Message == message {
+		return nil
+	}
+
+	log.Info("Recording scale up stalled condition", "names", runnerNames(stalled))
+	return patch(ctx, r.Status(), runnerSet, func(obj *v1alpha1.EphemeralRunnerSet) {
+		apimeta.SetStatusCondition(&obj.Status.Conditions, metav1.Condition{
+			Type:    v1alpha1.EphemeralRunnerSetConditionTypeScaleUpStalled,
+			Status:  metav1.ConditionTrue,
+			Reason:  "RegistrationTimeoutExceeded",
+			Message: message,
+		})
+	})
+}
+
+// clearScaleUpStalled clears a previously-recorded ScaleUpStalled condition once registration
+// health has recovered. Like recordScaleUpStalled, this mutates runnerSet.Status.Conditions in
+// place so the clear survives the Reconcile-level status aggregation that runs afterward.
+func (r *EphemeralRunnerSetReconciler) clearScaleUpStalled(ctx context.Context, runnerSet *v1alpha1.EphemeralRunnerSet, log logr.Logger) error {
+	existing := apimeta.FindStatusCondition(runnerSet.Status.Conditions, v1alpha1.EphemeralRunnerSetConditionTypeScaleUpStalled)
+	if existing == nil || existing.Status == metav1.ConditionFalse {
+		return nil
+	}
+
+	log.Info("Clearing scale up stalled condition")
+	return patch(ctx, r.Status(), runnerSet, func(obj *v1alpha1.EphemeralRunnerSet) {
+		apimeta.SetStatusCondition(&obj.Status.Conditions, metav1.Condition{
+			Type:    v1alpha1.EphemeralRunnerSetConditionTypeScaleUpStalled,
+			Status:  metav1.ConditionFalse,
+			Reason:  "RegistrationHealthy",
+			Message: "No EphemeralRunners are stuck registering",
+		})
+	})
+}
+
+// ephemeralRunnerPendingScaleDownAnnotationName marks a busy EphemeralRunner as selected for
+// scale down. Only this controller's DrainTimeout bookkeeping reads it; nothing in this
+// controller stops a runner carrying it from picking up another job while draining. The value
+// is the RFC3339 timestamp of when the runner was first marked, so we can tell how long it has
+// been draining.
+const ephemeralRunnerPendingScaleDownAnnotationName = "actions.github.com/pending-scaledown"
+
+// unmarkStaleScaleDownAnnotations clears the pending-scaledown annotation from any of the given
+// EphemeralRunners that still carry it, for reconciles where the EphemeralRunnerSet is not
+// scaling down. Without this, a runner marked for drain by a previous scale down that never
+// reached DrainTimeout before the set scaled back up (or settled at its desired replica count)
+// would keep the annotation, and a later scale down would measure DrainTimeout from that old
+// timestamp instead of from when it is selected again.
+func (r *EphemeralRunnerSetReconciler) unmarkStaleScaleDownAnnotations(ctx context.Context, pending, running []*v1alpha1.EphemeralRunner, log logr.Logger) error {
+	var errs []error
+	for _, ephemeralRunner := range pending {
+		if err := r.unmarkScaleDownAnnotation(ctx, ephemeralRunner, log); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for _, ephemeralRunner := range running {
+		if err := r.unmarkScaleDownAnnotation(ctx, ephemeralRunner, log); err != nil {
+			errs = append(errs, err)
+		}
+	}
 	return multierr.Combine(errs...)
 }
 
+// unmarkScaleDownAnnotation removes the pending-scaledown annotation from ephemeralRunner if
+// present, a no-op otherwise.
+func (r *EphemeralRunnerSetReconciler) unmarkScaleDownAnnotation(ctx context.Context, ephemeralRunner *v1alpha1.EphemeralRunner, log logr.Logger) error {
+	if _, ok := ephemeralRunner.Annotations[ephemeralRunnerPendingScaleDownAnnotationName]; !ok {
+		return nil
+	}
+
+	log.Info("Clearing stale pending-scaledown annotation", "name", ephemeralRunner.Name)
+	if err := patch(ctx, r.Client, ephemeralRunner, func(obj *v1alpha1.EphemeralRunner) {
+		delete(obj.Annotations, ephemeralRunnerPendingScaleDownAnnotationName)
+	}); err != nil && !kerrors.IsNotFound(err) {
+		return fmt.Errorf("failed to clear pending-scaledown annotation on ephemeral runner %q: %w", ephemeralRunner.Name, err)
+	}
+	return nil
+}
+
 // deleteIdleEphemeralRunners try to deletes `count` number of v1alpha1.EphemeralRunner resources in the cluster.
 // It will only delete `v1alpha1.EphemeralRunner` that has registered with Actions service
 // which has a `v1alpha1.EphemeralRunner.Status.RunnerId` set.
+// Busy runners (JobRequestId > 0) are instead drained according to the EphemeralRunnerSet's
+// ScaleDownPolicy: they are marked pending-scaledown and requeued until DrainTimeout elapses,
+// at which point OnDrainTimeout decides whether they are force-deleted or left running. Marking
+// a busy runner counts against count/MaxUnavailable exactly like a deletion does, so a single
+// pass only ever claims up to that many runners regardless of how many are busy versus idle.
 // So, it is possible that this function will not delete enough ephemeral runners
 // if there are not enough ephemeral runners that have registered with Actions service.
 // When this happens, the next reconcile loop will try to delete the remaining ephemeral runners
 // after we get notified by any of the `v1alpha1.EphemeralRunner.Status` updates.
-func (r *EphemeralRunnerSetReconciler) deleteIdleEphemeralRunners(ctx context.Context, ephemeralRunnerSet *v1alpha1.EphemeralRunnerSet, pendingEphemeralRunners, runningEphemeralRunners []*v1alpha1.EphemeralRunner, count int, log logr.Logger) error {
-	runners := newEphemeralRunnerStepper(pendingEphemeralRunners, runningEphemeralRunners)
+func (r *EphemeralRunnerSetReconciler) deleteIdleEphemeralRunners(ctx context.Context, ephemeralRunnerSet *v1alpha1.EphemeralRunnerSet, pendingEphemeralRunners, runningEphemeralRunners []*v1alpha1.EphemeralRunner, count int, log logr.Logger) (ctrl.Result, error) {
+	policy := ephemeralRunnerSet.Spec.ScaleDownPolicy
+	strategy := scaleDownStrategyFor(policy.Strategy)
+	runners := newEphemeralRunnerStepper(pendingEphemeralRunners, runningEphemeralRunners, strategy)
 	if runners.len() == 0 {
 		log.Info("No pending or running ephemeral runners running at this time for scale down")
-		return nil
+		return ctrl.Result{}, nil
+	}
+
+	maxUnavailable := count
+	if policy.MaxUnavailable != nil {
+		resolved, err := intstr.GetScaledValueFromIntOrPercent(policy.MaxUnavailable, runners.len(), true)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to resolve scaleDownPolicy.maxUnavailable: %w", err)
+		}
+		if resolved < maxUnavailable {
+			maxUnavailable = resolved
+		}
 	}
+
 	actionsClient, err := r.actionsClientFor(ctx, ephemeralRunnerSet)
 	if err != nil {
-		return fmt.Errorf("failed to create actions client for ephemeral runner replica set: %v", err)
+		return ctrl.Result{}, fmt.Errorf("failed to create actions client for ephemeral runner replica set: %v", err)
 	}
+
 	var errs []error
-	deletedCount := 0
+	var requeueAfter time.Duration
+	// claimedCount tracks runners claimed toward this pass's scale-down budget, whether they
+	// were actually deleted or only marked pending-scaledown. Marking a busy runner must count
+	// here too: otherwise the loop has no bound on how many busy runners it drains in a single
+	// pass and walks the entire candidate list instead of stopping at maxUnavailable.
+	claimedCount := 0
 	for runners.next() {
+		if claimedCount == maxUnavailable {
+			break
+		}
+
 		ephemeralRunner := runners.object()
 		if ephemeralRunner.Status.RunnerId == 0 {
 			log.Info("Skipping ephemeral runner since it is not registered yet", "name", ephemeralRunner.Name)
@@ -314,7 +725,22 @@ func (r *EphemeralRunnerSetReconciler) deleteIdleEphemeralRunners(ctx context.Co
 		}
 
 		if ephemeralRunner.Status.JobRequestId > 0 {
-			log.Info("Skipping ephemeral runner since it is running a job", "name", ephemeralRunner.Name, "jobRequestId", ephemeralRunner.Status.JobRequestId)
+			after, deleted, err := r.drainBusyEphemeralRunner(ctx, ephemeralRunner, policy, actionsClient, log)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			if policy.DrainTimeout.Duration <= 0 {
+				// Skipped, not marked: it does not count toward this pass's budget.
+				continue
+			}
+			claimedCount++
+			if deleted {
+				continue
+			}
+			if after > 0 && (requeueAfter == 0 || after < requeueAfter) {
+				requeueAfter = after
+			}
 			continue
 		}
 
@@ -327,13 +753,65 @@ func (r *EphemeralRunnerSetReconciler) deleteIdleEphemeralRunners(ctx context.Co
 			continue
 		}
 
-		deletedCount++
-		if deletedCount == count {
-			break
+		claimedCount++
+	}
+
+	return ctrl.Result{RequeueAfter: requeueAfter}, multierr.Combine(errs...)
+}
+
+// drainBusyEphemeralRunner marks a busy EphemeralRunner as pending scale down (see
+// ephemeralRunnerPendingScaleDownAnnotationName for the caveat that nothing yet stops it from
+// picking up another job while marked), and either requeues for another look once
+// DrainTimeout/10 has passed, or applies OnDrainTimeout once DrainTimeout has elapsed. A
+// DrainTimeout of zero preserves the historical behavior of skipping busy runners indefinitely.
+// deleted reports whether the EphemeralRunner was actually removed this call, in which case the
+// caller does not requeue for it.
+func (r *EphemeralRunnerSetReconciler) drainBusyEphemeralRunner(ctx context.Context, ephemeralRunner *v1alpha1.EphemeralRunner, policy v1alpha1.ScaleDownPolicy, actionsClient actions.ActionsService, log logr.Logger) (requeueAfter time.Duration, deleted bool, err error) {
+	if policy.DrainTimeout.Duration <= 0 {
+		log.Info("Skipping ephemeral runner since it is running a job", "name", ephemeralRunner.Name, "jobRequestId", ephemeralRunner.Status.JobRequestId)
+		return 0, false, nil
+	}
+
+	markedAt, ok := ephemeralRunner.Annotations[ephemeralRunnerPendingScaleDownAnnotationName]
+	if !ok {
+		log.Info("Marking busy ephemeral runner pending scale down", "name", ephemeralRunner.Name, "jobRequestId", ephemeralRunner.Status.JobRequestId)
+		if err := patch(ctx, r.Client, ephemeralRunner, func(obj *v1alpha1.EphemeralRunner) {
+			if obj.Annotations == nil {
+				obj.Annotations = map[string]string{}
+			}
+			obj.Annotations[ephemeralRunnerPendingScaleDownAnnotationName] = metav1.Now().UTC().Format(time.RFC3339)
+		}); err != nil {
+			return 0, false, fmt.Errorf("failed to mark ephemeral runner %q pending scale down: %w", ephemeralRunner.Name, err)
 		}
+		return policy.DrainTimeout.Duration / 10, false, nil
 	}
 
-	return multierr.Combine(errs...)
+	since, err := time.Parse(time.RFC3339, markedAt)
+	if err != nil {
+		log.Error(err, "Failed to parse pending-scaledown annotation, resetting", "name", ephemeralRunner.Name)
+		if err := patch(ctx, r.Client, ephemeralRunner, func(obj *v1alpha1.EphemeralRunner) {
+			obj.Annotations[ephemeralRunnerPendingScaleDownAnnotationName] = metav1.Now().UTC().Format(time.RFC3339)
+		}); err != nil {
+			return 0, false, fmt.Errorf("failed to reset pending-scaledown annotation on ephemeral runner %q: %w", ephemeralRunner.Name, err)
+		}
+		return policy.DrainTimeout.Duration / 10, false, nil
+	}
+
+	if time.Since(since) < policy.DrainTimeout.Duration {
+		return policy.DrainTimeout.Duration / 10, false, nil
+	}
+
+	switch policy.OnDrainTimeout {
+	case v1alpha1.DrainTimeoutActionForce:
+		log.Info("DrainTimeout elapsed, force deleting busy ephemeral runner", "name", ephemeralRunner.Name, "jobRequestId", ephemeralRunner.Status.JobRequestId)
+		if err := r.forceDeleteEphemeralRunner(ctx, ephemeralRunner, actionsClient, log); err != nil {
+			return 0, false, fmt.Errorf("failed to force delete ephemeral runner %q: %w", ephemeralRunner.Name, err)
+		}
+		return 0, true, nil
+	default:
+		log.Info("DrainTimeout elapsed, preserving busy ephemeral runner", "name", ephemeralRunner.Name, "jobRequestId", ephemeralRunner.Status.JobRequestId)
+		return policy.DrainTimeout.Duration / 10, false, nil
+	}
 }
 
 func (r *EphemeralRunnerSetReconciler) deleteEphemeralRunnerWithActionsClient(ctx context.Context, ephemeralRunner *v1alpha1.EphemeralRunner, actionsClient actions.ActionsService, log logr.Logger) (bool, error) {
@@ -358,6 +836,32 @@ func (r *EphemeralRunnerSetReconciler) deleteEphemeralRunnerWithActionsClient(ct
 	return true, nil
 }
 
+// forceDeleteEphemeralRunner tears down a busy EphemeralRunner immediately, abandoning its
+// in-flight job, which is what distinguishes DrainTimeoutActionForce from
+// deleteEphemeralRunnerWithActionsClient's wait-until-the-service-agrees-the-job-is-done
+// behavior. It still makes a best-effort attempt to deregister the runner from the Actions
+// service first, but unlike deleteEphemeralRunnerWithActionsClient, a JobStillRunningException
+// does not abort the deletion: Force means the Kubernetes resource goes away now regardless of
+// job state.
+func (r *EphemeralRunnerSetReconciler) forceDeleteEphemeralRunner(ctx context.Context, ephemeralRunner *v1alpha1.EphemeralRunner, actionsClient actions.ActionsService, log logr.Logger) error {
+	if err := actionsClient.RemoveRunner(ctx, int64(ephemeralRunner.Status.RunnerId)); err != nil {
+		actionsError := &actions.ActionsError{}
+		if !errors.As(err, &actionsError) ||
+			actionsError.StatusCode != http.StatusBadRequest ||
+			!strings.Contains(actionsError.ExceptionName, "JobStillRunningException") {
+			return fmt.Errorf("failed to remove ephemeral runner %q from the service: %w", ephemeralRunner.Name, err)
+		}
+		log.Info("Actions service reports job still running, force deleting anyway", "name", ephemeralRunner.Name)
+	}
+
+	if err := r.Delete(ctx, ephemeralRunner); err != nil && !kerrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete ephemeral runner %q: %w", ephemeralRunner.Name, err)
+	}
+
+	log.Info("Force deleted ephemeral runner", "name", ephemeralRunner.Name)
+	return nil
+}
+
 func (r *EphemeralRunnerSetReconciler) actionsClientFor(ctx context.Context, rs *v1alpha1.EphemeralRunnerSet) (actions.ActionsService, error) {
 	secret := new(corev1.Secret)
 	if err := r.Get(ctx, types.NamespacedName{Namespace: rs.Namespace, Name: rs.Spec.EphemeralRunnerSpec.GitHubConfigSecret}, secret); err != nil {
@@ -393,26 +897,128 @@ func (r *EphemeralRunnerSetReconciler) SetupWithManager(mgr ctrl.Manager) error
 
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&v1alpha1.EphemeralRunnerSet{}).
+		// Owning the EphemeralRunner means we are notified on every status update the
+		// EphemeralRunner controller makes, including phase and job assignment changes,
+		// so the aggregated status above stays fresh without a dedicated poll loop.
 		Owns(&v1alpha1.EphemeralRunner{}).
+		// EphemeralRunner's pods are not owned by this EphemeralRunnerSet directly, so we
+		// need an explicit watch to notice phase transitions (e.g. Running -> Succeeded)
+		// as soon as they happen rather than waiting for the next scaling reconcile.
+		Watches(
+			&corev1.Pod{},
+			handler.EnqueueRequestsFromMapFunc(r.podEphemeralRunnerSet),
+		).
 		WithEventFilter(predicate.ResourceVersionChangedPredicate{}).
 		Complete(r)
 }
 
-type ephemeralRunnerStepper struct {
-	items []*v1alpha1.EphemeralRunner
-	index int
+// podEphemeralRunnerSet maps an EphemeralRunner's pod back to the owning EphemeralRunnerSet,
+// so pod phase changes feed into this reconciler's status aggregation.
+func (r *EphemeralRunnerSetReconciler) podEphemeralRunnerSet(ctx context.Context, obj client.Object) []ctrl.Request {
+	pod, ok := obj.(*corev1.Pod)
+	if !ok {
+		return nil
+	}
+
+	runnerOwner := metav1.GetControllerOf(pod)
+	if runnerOwner == nil || runnerOwner.Kind != "EphemeralRunner" {
+		return nil
+	}
+
+	ephemeralRunner := new(v1alpha1.EphemeralRunner)
+	if err := r.Get(ctx, types.NamespacedName{Namespace: pod.Namespace, Name: runnerOwner.Name}, ephemeralRunner); err != nil {
+		return nil
+	}
+
+	setOwner := metav1.GetControllerOf(ephemeralRunner)
+	if setOwner == nil || setOwner.Kind != "EphemeralRunnerSet" {
+		return nil
+	}
+
+	return []ctrl.Request{
+		{NamespacedName: types.NamespacedName{Namespace: pod.Namespace, Name: setOwner.Name}},
+	}
+}
+
+// scaleDownStrategy orders the pending and running EphemeralRunners considered for deletion
+// during a scale down. Implementations must not assume pending and running arrive pre-sorted.
+type scaleDownStrategy interface {
+	order(pending, running []*v1alpha1.EphemeralRunner) []*v1alpha1.EphemeralRunner
+}
+
+// scaleDownStrategyFor resolves a v1alpha1.ScaleDownStrategy to its implementation, defaulting
+// to oldestFirstStrategy to preserve this controller's historical behavior.
+func scaleDownStrategyFor(strategy v1alpha1.ScaleDownStrategy) scaleDownStrategy {
+	switch strategy {
+	case v1alpha1.ScaleDownStrategyNewestFirst:
+		return newestFirstStrategy{}
+	case v1alpha1.ScaleDownStrategyLeastRecentlyStarted:
+		return leastRecentlyStartedStrategy{}
+	case v1alpha1.ScaleDownStrategyRandom:
+		return randomStrategy{}
+	default:
+		return oldestFirstStrategy{}
+	}
+}
+
+func byCreationTimestamp(pending, running []*v1alpha1.EphemeralRunner, ascending bool) []*v1alpha1.EphemeralRunner {
+	items := append(append([]*v1alpha1.EphemeralRunner{}, pending...), running...)
+	sort.Slice(items, func(i, j int) bool {
+		if ascending {
+			return items[i].GetCreationTimestamp().Time.Before(items[j].GetCreationTimestamp().Time)
+		}
+		return items[j].GetCreationTimestamp().Time.Before(items[i].GetCreationTimestamp().Time)
+	})
+	return items
+}
+
+// oldestFirstStrategy deletes pending EphemeralRunners before running ones, the longest-lived
+// first within each group.
+type oldestFirstStrategy struct{}
+
+func (oldestFirstStrategy) order(pending, running []*v1alpha1.EphemeralRunner) []*v1alpha1.EphemeralRunner {
+	return append(byCreationTimestamp(pending, nil, true), byCreationTimestamp(running, nil, true)...)
 }
 
-func newEphemeralRunnerStepper(pending, running []*v1alpha1.EphemeralRunner) *ephemeralRunnerStepper {
-	sort.Slice(pending, func(i, j int) bool {
-		return pending[i].GetCreationTimestamp().Time.Before(pending[j].GetCreationTimestamp().Time)
+// newestFirstStrategy deletes the most recently created EphemeralRunners first.
+type newestFirstStrategy struct{}
+
+func (newestFirstStrategy) order(pending, running []*v1alpha1.EphemeralRunner) []*v1alpha1.EphemeralRunner {
+	return byCreationTimestamp(pending, running, false)
+}
+
+// leastRecentlyStartedStrategy deletes EphemeralRunners that have gone the longest without
+// picking up a job first, so runners actively cycling through jobs are left alone.
+type leastRecentlyStartedStrategy struct{}
+
+func (leastRecentlyStartedStrategy) order(pending, running []*v1alpha1.EphemeralRunner) []*v1alpha1.EphemeralRunner {
+	items := append(append([]*v1alpha1.EphemeralRunner{}, pending...), running...)
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].Status.JobStartedAt.Time.Before(items[j].Status.JobStartedAt.Time)
 	})
-	sort.Slice(running, func(i, j int) bool {
-		return running[i].GetCreationTimestamp().Time.Before(running[j].GetCreationTimestamp().Time)
+	return items
+}
+
+// randomStrategy deletes EphemeralRunners in a random order, useful for spreading scale down
+// evenly across node pools or availability zones rather than biasing by age.
+type randomStrategy struct{}
+
+func (randomStrategy) order(pending, running []*v1alpha1.EphemeralRunner) []*v1alpha1.EphemeralRunner {
+	items := append(append([]*v1alpha1.EphemeralRunner{}, pending...), running...)
+	rand.Shuffle(len(items), func(i, j int) {
+		items[i], items[j] = items[j], items[i]
 	})
+	return items
+}
 
+type ephemeralRunnerStepper struct {
+	items []*v1alpha1.EphemeralRunner
+	index int
+}
+
+func newEphemeralRunnerStepper(pending, running []*v1alpha1.EphemeralRunner, strategy scaleDownStrategy) *ephemeralRunnerStepper {
 	return &ephemeralRunnerStepper{
-		items: append(pending, running...),
+		items: strategy.order(pending, running),
 		index: -1,
 	}
 }
@@ -460,4 +1066,4 @@ func categorizeEphemeralRunners(ephemeralRunnerList *v1alpha1.EphemeralRunnerLis
 		}
 	}
 	return
-}
\ No newline at end of file
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2020 The actions-runner-controller authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package finalizers provides a shared helper for the "add finalizer, patch, return early"
+// step that actions.github.com controllers perform at the top of Reconcile.
+//
+// EphemeralRunnerSetReconciler is the only caller of EnsureFinalizer in this tree; the
+// EphemeralRunner, AutoscalingRunnerSet, and AutoscalingListener reconcilers are not present
+// here and do not call it.
+package finalizers
+
+import (
+	"context"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// EnsureFinalizer adds the named finalizer to obj if it is missing, via a single
+// conflict-aware patch, and reports whether it did so. Reconcilers should call this right after
+// fetching obj, before any other Get/List work, and return early when added is true so the
+// finalizer patch doesn't race with the rest of the reconcile.
+//
+// EnsureFinalizer is a no-op, returning added=false, when obj is already being deleted, and
+// tolerates obj having been deleted between the caller's Get and this patch by treating
+// IsNotFound as success rather than an error.
+func EnsureFinalizer(ctx context.Context, c client.Client, obj client.Object, name string) (added bool, err error) {
+	if !obj.GetDeletionTimestamp().IsZero() {
+		return false, nil
+	}
+
+	if controllerutil.ContainsFinalizer(obj, name) {
+		return false, nil
+	}
+
+	original := obj.DeepCopyObject().(client.Object)
+	controllerutil.AddFinalizer(obj, name)
+	if err := c.Patch(ctx, obj, client.MergeFromWithOptions(original, client.MergeFromWithOptimisticLock{})); err != nil {
+		if kerrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}